@@ -3,14 +3,12 @@ package main
 import (
 	"context"
 	"embed"
-	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
-	"time"
 
+	"github.com/Tanaaz-Cyr/MDViewer/tts"
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
@@ -25,9 +23,15 @@ type App struct {
 	ctx         context.Context
 	currentFile string
 	content     string
-	ttsProcess  *exec.Cmd
 	ttsMutex    sync.Mutex
 	isSpeaking  bool
+	speaker     tts.Speaker
+	ttsEngine   TTSEngine
+
+	watchMu         sync.Mutex
+	watcher         *fileWatcher
+	watchEnabled    bool
+	watchDebounceMs int
 }
 
 // NewApp creates a new App application struct
@@ -38,6 +42,38 @@ func NewApp() *App {
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+
+	if apiKey := os.Getenv("MDVIEWER_GOOGLE_TTS_API_KEY"); apiKey != "" {
+		// Cloud TTS reports real synthesis timepoints for SpeakLineStreaming,
+		// so prefer it for live speaking whenever it's configured, not just
+		// for document export.
+		a.speaker = tts.NewCloudEngine(apiKey)
+		a.ttsEngine = NewGoogleCloudEngine(apiKey)
+	} else {
+		speaker, err := tts.New("")
+		if err != nil {
+			// DefaultEngineName always returns a name New() knows, so this
+			// should be unreachable; if it does happen, a.speaker is left
+			// nil and the error is only logged.
+			println("tts: failed to initialize default engine:", err.Error())
+		}
+		a.speaker = speaker
+		a.ttsEngine = SayEngine{}
+	}
+}
+
+// SetTTSEngine switches the live-speaking backend by name ("say", "linux",
+// "windows", "piper"), overriding the runtime.GOOS-based default.
+func (a *App) SetTTSEngine(name string) error {
+	speaker, err := tts.New(name)
+	if err != nil {
+		return err
+	}
+
+	a.ttsMutex.Lock()
+	a.speaker = speaker
+	a.ttsMutex.Unlock()
+	return nil
 }
 
 // OpenFileDialog opens a native file picker for markdown files
@@ -71,8 +107,7 @@ func (a *App) OpenFileDialog() (string, error) {
 		return "", err
 	}
 
-	a.currentFile = filePath
-	a.content = string(content)
+	a.setCurrentFile(filePath, string(content))
 
 	return filePath, nil
 }
@@ -106,8 +141,7 @@ func (a *App) SaveFileDialog(content string) (string, error) {
 		return "", err
 	}
 
-	a.currentFile = filePath
-	a.content = content
+	a.setCurrentFile(filePath, content)
 
 	return filePath, nil
 }
@@ -118,18 +152,27 @@ func (a *App) ReadFile(filepath string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	a.currentFile = filepath
-	a.content = string(content)
+	a.setCurrentFile(filepath, string(content))
 	return string(content), nil
 }
 
 // SaveFile saves content to a file
 func (a *App) SaveFile(filepath, content string) error {
-	a.currentFile = filepath
-	a.content = content
+	a.setCurrentFile(filepath, content)
 	return os.WriteFile(filepath, []byte(content), 0644)
 }
 
+// setCurrentFile updates the in-memory file/content and, if file watching
+// is enabled, atomically replaces the watcher so it tracks the new file.
+func (a *App) setCurrentFile(path string, content string) {
+	a.currentFile = path
+	a.content = content
+
+	a.watchMu.Lock()
+	_ = a.restartWatchLocked()
+	a.watchMu.Unlock()
+}
+
 // GetCurrentFile returns the current file path
 func (a *App) GetCurrentFile() string {
 	return a.currentFile
@@ -143,68 +186,97 @@ func (a *App) GetContent() string {
 // SpeakLine speaks a single line and waits for completion
 func (a *App) SpeakLine(text string, voice string, rate int) error {
 	a.ttsMutex.Lock()
-
-	// Check if empty line
 	if strings.TrimSpace(text) == "" {
 		a.ttsMutex.Unlock()
 		return nil // Skip empty lines silently
 	}
+	a.isSpeaking = true
+	speaker := a.speaker
+	a.ttsMutex.Unlock()
 
-	// Create temp file for audio output to ensure complete processing
-	tmpFile := fmt.Sprintf("/tmp/mdviewer_tts_%d.aiff", time.Now().UnixNano())
+	err := speaker.Speak(a.ctx, text, voice, rate)
 
-	var cmd *exec.Cmd
-	if voice != "" && voice != "default" {
-		// Use -o to write to file, then play - ensures complete processing
-		cmd = exec.Command("say", "-v", voice, "-r", fmt.Sprintf("%d", rate), "-o", tmpFile, "--file-format=AIFF", text)
-	} else {
-		cmd = exec.Command("say", "-r", fmt.Sprintf("%d", rate), "-o", tmpFile, "--file-format=AIFF", text)
+	a.ttsMutex.Lock()
+	a.isSpeaking = false
+	a.ttsMutex.Unlock()
+
+	return err
+}
+
+// SpeakLineStreaming speaks text like SpeakLine but additionally emits
+// tts:start, tts:boundary (per sentence) and tts:end events so the
+// renderer can karaoke-highlight the sentence currently being read.
+// tts:boundary is only emitted by backends that implement
+// tts.BoundarySpeaker; the others emit just tts:start and tts:end.
+func (a *App) SpeakLineStreaming(text string, voice string, rate int) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
 	}
 
-	a.ttsProcess = cmd
+	a.ttsMutex.Lock()
 	a.isSpeaking = true
-
-	// Unlock before blocking operation
+	speaker := a.speaker
 	a.ttsMutex.Unlock()
 
-	// Run and wait for file creation
-	err := cmd.Run()
-	
-	if err != nil {
-		a.ttsMutex.Lock()
-		a.isSpeaking = false
-		a.ttsProcess = nil
-		a.ttsMutex.Unlock()
-		return err
+	runtime.EventsEmit(a.ctx, "tts:start")
+
+	var err error
+	if streamer, ok := speaker.(tts.BoundarySpeaker); ok {
+		err = streamer.SpeakWithBoundaries(a.ctx, text, voice, rate, func(b tts.Boundary) {
+			runtime.EventsEmit(a.ctx, "tts:boundary", map[string]int{
+				"partIndex": b.PartIndex,
+				"charStart": b.CharStart,
+				"charEnd":   b.CharEnd,
+				"timeMs":    b.TimeMs,
+			})
+		})
+	} else {
+		err = speaker.Speak(a.ctx, text, voice, rate)
 	}
 
-	// Now play the complete audio file
-	playCmd := exec.Command("afplay", tmpFile)
-	err = playCmd.Run()
+	runtime.EventsEmit(a.ctx, "tts:end")
 
-	// Clean up temp file
-	os.Remove(tmpFile)
-
-	// Re-lock to update state
 	a.ttsMutex.Lock()
 	a.isSpeaking = false
-	a.ttsProcess = nil
 	a.ttsMutex.Unlock()
 
 	return err
 }
 
+// Pause suspends in-progress playback so Resume can continue it from the
+// same position.
+func (a *App) Pause() error {
+	a.ttsMutex.Lock()
+	speaker := a.speaker
+	a.ttsMutex.Unlock()
+
+	if err := speaker.Pause(); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "tts:pause")
+	return nil
+}
+
+// Resume continues playback previously suspended with Pause.
+func (a *App) Resume() error {
+	a.ttsMutex.Lock()
+	speaker := a.speaker
+	a.ttsMutex.Unlock()
+
+	return speaker.Resume()
+}
+
 // Stop stops the current TTS
 func (a *App) Stop() error {
 	a.ttsMutex.Lock()
 	defer a.ttsMutex.Unlock()
 
-	if a.ttsProcess != nil {
-		// Kill the say process
-		exec.Command("pkill", "-f", "say").Run()
-		a.ttsProcess = nil
-		a.isSpeaking = false
+	if a.speaker != nil {
+		if err := a.speaker.Stop(); err != nil {
+			return err
+		}
 	}
+	a.isSpeaking = false
 	return nil
 }
 
@@ -216,29 +288,13 @@ func (a *App) IsSpeaking() bool {
 }
 
 // GetAvailableVoices returns list of available TTS voices
-func (a *App) GetAvailableVoices() ([]string, error) {
-	cmd := exec.Command("say", "-v", "?")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
-
-	voices := []string{}
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			parts := strings.Fields(line)
-			if len(parts) > 0 {
-				voiceName := parts[0]
-				voices = append(voices, voiceName)
-			}
-		}
-	}
-	return voices, nil
+func (a *App) GetAvailableVoices() ([]tts.Voice, error) {
+	return a.speaker.Voices()
 }
 
 func main() {
 	app := NewApp()
+	installSignalHandler(app)
 
 	err := wails.Run(&options.App{
 		Title:     "MDViewer",
@@ -251,6 +307,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 30, G: 38, B: 46, A: 1},
 		OnStartup:        app.startup,
+		OnShutdown:       app.shutdown,
 		Bind: []interface{}{
 			app,
 		},
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Tanaaz-Cyr/MDViewer/tts"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// chapterSection is one heading-delimited section of the document, ready
+// for synthesis.
+type chapterSection struct {
+	Title string
+	Body  string
+}
+
+// parseChapters walks the markdown AST and splits the document at each
+// H1/H2 heading, using the heading text as the chapter title. Documents
+// with no headings become a single untitled chapter.
+func parseChapters(markdown string) []chapterSection {
+	source := []byte(markdown)
+	doc := goldmark.DefaultParser().Parse(text.NewReader(source))
+
+	var chapters []chapterSection
+	var current *chapterSection
+	var body strings.Builder
+
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(body.String())
+			chapters = append(chapters, *current)
+		}
+		body.Reset()
+	}
+
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if h, ok := n.(*ast.Heading); ok && (h.Level == 1 || h.Level == 2) {
+			flush()
+			current = &chapterSection{Title: string(h.Text(source))}
+			return ast.WalkSkipChildren, nil
+		}
+		if t, ok := n.(*ast.Text); ok {
+			if current == nil {
+				// Text before the first heading becomes an untitled chapter.
+				current = &chapterSection{}
+			}
+			body.Write(t.Segment.Value(source))
+			body.WriteString(" ")
+		}
+		return ast.WalkContinue, nil
+	})
+	flush()
+
+	if len(chapters) == 0 {
+		chapters = append(chapters, chapterSection{Body: markdown})
+	}
+	return chapters
+}
+
+// chapterAudioData is a chapter's title and estimated playback offsets
+// paired with its fully-synthesized audio, ready to be concatenated into
+// the export output.
+type chapterAudioData struct {
+	title   string
+	audio   []byte
+	startMs int
+	endMs   int
+}
+
+// ExportAudio synthesizes the current document to a single audio file at
+// outPath (prompting via a save dialog when outPath is empty), splitting
+// it into chapters at each H1/H2 heading.
+func (a *App) ExportAudio(outPath string, format string, voice string, rate int) error {
+	format = strings.ToLower(format)
+
+	if outPath == "" {
+		var err error
+		outPath, err = runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           "Export Audio",
+			DefaultFilename: "document." + format,
+			Filters: []runtime.FileFilter{
+				{DisplayName: strings.ToUpper(format) + " Audio", Pattern: "*." + format},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if outPath == "" {
+			return nil
+		}
+	}
+
+	chapters := parseChapters(a.content)
+	total := len(chapters)
+	rendered := make([]chapterAudioData, 0, total)
+
+	elapsedMs := 0
+	for i, ch := range chapters {
+		startMs := elapsedMs
+		var buf bytes.Buffer
+		for _, p := range splitParagraphs(ch.Body) {
+			part, err := a.synthesizeCached(a.ctx, p, voice, rate)
+			if err != nil {
+				return fmt.Errorf("synthesizing chapter %q: %w", ch.Title, err)
+			}
+			buf.Write(part)
+			elapsedMs += tts.EstimateDurationMs(p, rate)
+		}
+		rendered = append(rendered, chapterAudioData{title: ch.Title, audio: buf.Bytes(), startMs: startMs, endMs: elapsedMs})
+
+		runtime.EventsEmit(a.ctx, "tts:export-progress", map[string]int{"done": i + 1, "total": total})
+	}
+
+	switch format {
+	case "mp3":
+		return exportMP3WithChapters(rendered, outPath)
+	case "wav", "aiff":
+		return exportViaFFmpeg(rendered, outPath)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// exportMP3WithChapters concatenates each chapter's (already-MP3) audio and
+// prepends an ID3v2 tag with CHAP/CTOC frames recording each chapter's title
+// and timing. Byte offsets aren't filled in: the tag is prepended to the
+// audio after its own length is known, which is circular with computing
+// offsets into the final file, so StartOffset/EndOffset are left at the
+// spec's "not indicated" sentinel and players are expected to seek by time.
+func exportMP3WithChapters(chapters []chapterAudioData, outPath string) error {
+	var audio bytes.Buffer
+	markers := make([]chapterMarker, 0, len(chapters))
+	for _, ch := range chapters {
+		audio.Write(ch.audio)
+		markers = append(markers, chapterMarker{
+			Title:       ch.title,
+			StartMs:     ch.startMs,
+			EndMs:       ch.endMs,
+			StartOffset: noOffset,
+			EndOffset:   noOffset,
+		})
+	}
+
+	id3 := buildID3v2Chapters(markers)
+	return os.WriteFile(outPath, append(id3, audio.Bytes()...), 0644)
+}
+
+// exportViaFFmpeg concatenates chapters of arbitrary/mixed source encoding
+// into outPath using ffmpeg's concat filter, letting ffmpeg pick the output
+// codec from outPath's extension (WAV or AIFF).
+func exportViaFFmpeg(chapters []chapterAudioData, outPath string) error {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return fmt.Errorf("exporting to %s requires ffmpeg on PATH: %w", filepath.Ext(outPath), err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "mdviewer-export")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := []string{"-y"}
+	var filterInputs strings.Builder
+	for i, ch := range chapters {
+		inPath := filepath.Join(tmpDir, fmt.Sprintf("chapter_%d.part", i))
+		if err := os.WriteFile(inPath, ch.audio, 0644); err != nil {
+			return err
+		}
+		args = append(args, "-i", inPath)
+		fmt.Fprintf(&filterInputs, "[%d:a]", i)
+	}
+	filter := fmt.Sprintf("%sconcat=n=%d:v=0:a=1[out]", filterInputs.String(), len(chapters))
+	args = append(args, "-filter_complex", filter, "-map", "[out]", outPath)
+
+	return exec.Command("ffmpeg", args...).Run()
+}
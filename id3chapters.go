@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// noOffset is the ID3v2 Chapter Frame Addendum's sentinel for "offset not
+// indicated" (0xFFFFFFFF), used when a byte offset into the final file isn't
+// known at tag-build time.
+const noOffset = 0xFFFFFFFF
+
+// chapterMarker describes one chapter's title, timing and (optionally) its
+// start/end byte offset into the audio stream for an ID3v2 CHAP frame.
+// StartOffset/EndOffset are written as given, so callers set them to
+// noOffset when the offset is unknown; left unset they are written as 0.
+type chapterMarker struct {
+	Title       string
+	StartMs     int
+	EndMs       int
+	StartOffset uint32
+	EndOffset   uint32
+}
+
+// buildID3v2Chapters renders an ID3v2.3 tag containing a CTOC frame
+// referencing one CHAP frame per chapter, each carrying a TIT2 title
+// sub-frame, per the ID3v2 Chapter Frame Addendum.
+func buildID3v2Chapters(chapters []chapterMarker) []byte {
+	var frames bytes.Buffer
+
+	childIDs := make([]string, len(chapters))
+	for i, ch := range chapters {
+		id := chapterElementID(i)
+		childIDs[i] = id
+		frames.Write(encodeFrame("CHAP", encodeChapFrame(id, ch)))
+	}
+	frames.Write(encodeFrame("CTOC", encodeCtocFrame(childIDs)))
+
+	return encodeID3v2Header(frames.Bytes())
+}
+
+func chapterElementID(index int) string {
+	return "chp" + itoa(index)
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	digits := []byte{}
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	return string(digits)
+}
+
+// encodeChapFrame builds a CHAP frame body: element ID, start/end time,
+// start/end byte offset, followed by a TIT2 title sub-frame.
+func encodeChapFrame(id string, ch chapterMarker) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(id)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.BigEndian, uint32(ch.StartMs))
+	binary.Write(&buf, binary.BigEndian, uint32(ch.EndMs))
+	binary.Write(&buf, binary.BigEndian, ch.StartOffset)
+	binary.Write(&buf, binary.BigEndian, ch.EndOffset)
+	buf.Write(encodeFrame("TIT2", encodeTextFrame(ch.Title)))
+	return buf.Bytes()
+}
+
+// encodeCtocFrame builds the top-level CTOC frame listing every chapter's
+// element ID in playback order.
+func encodeCtocFrame(childIDs []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("toc")
+	buf.WriteByte(0)
+	buf.WriteByte(0x03) // top-level + ordered
+	buf.WriteByte(byte(len(childIDs)))
+	for _, id := range childIDs {
+		buf.WriteString(id)
+		buf.WriteByte(0)
+	}
+	return buf.Bytes()
+}
+
+// encodeTextFrame encodes a text frame body as UTF-16LE with a leading BOM
+// (encoding 1). Chapter titles come straight from markdown heading text,
+// which is routinely outside ISO-8859-1 (smart quotes, non-Latin scripts),
+// so declaring and emitting ASCII-only encoding 0 would mangle them.
+func encodeTextFrame(text string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(1) // UTF-16 with BOM
+	buf.Write([]byte{0xFF, 0xFE}) // little-endian BOM
+	for _, unit := range utf16.Encode([]rune(text)) {
+		binary.Write(&buf, binary.LittleEndian, unit)
+	}
+	return buf.Bytes()
+}
+
+// encodeFrame wraps a frame body with its ID3v2.3 frame header (ID, size,
+// flags).
+func encodeFrame(id string, body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(id)
+	binary.Write(&buf, binary.BigEndian, uint32(len(body)))
+	buf.Write([]byte{0, 0}) // flags
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// encodeID3v2Header wraps encoded frames with an ID3v2.3 tag header using a
+// synchsafe size.
+func encodeID3v2Header(frames []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("ID3")
+	buf.Write([]byte{3, 0}) // version 2.3.0
+	buf.WriteByte(0)        // flags
+	buf.Write(synchsafe(uint32(len(frames))))
+	buf.Write(frames)
+	return buf.Bytes()
+}
+
+// synchsafe encodes n as a 4-byte synchsafe integer (7 significant bits per
+// byte), as required for the overall ID3v2 tag size.
+func synchsafe(n uint32) []byte {
+	return []byte{
+		byte((n >> 21) & 0x7F),
+		byte((n >> 14) & 0x7F),
+		byte((n >> 7) & 0x7F),
+		byte(n & 0x7F),
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// installSignalHandler arranges for SIGINT/SIGTERM to trigger the same
+// graceful shutdown (stop TTS, clean temp files) as closing the window,
+// instead of leaving the child say/afplay process and its temp file
+// behind. SIGHUP is ignored, per the usual daemon convention of not dying
+// when the controlling terminal goes away.
+func installSignalHandler(a *App) {
+	signal.Ignore(syscall.SIGHUP)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigCh
+		if a.ctx != nil {
+			runtime.Quit(a.ctx)
+		}
+	}()
+}
+
+// shutdown runs when Wails tears the app down, whether that was triggered
+// by the window closing or by installSignalHandler reacting to SIGINT/
+// SIGTERM. It stops any in-flight speech and removes leftover TTS temp
+// files so a killed app doesn't leak them or leave afplay/say running.
+func (a *App) shutdown(ctx context.Context) {
+	_ = a.Stop()
+
+	a.watchMu.Lock()
+	if a.watcher != nil {
+		a.watcher.Close()
+		a.watcher = nil
+	}
+	a.watchMu.Unlock()
+
+	cleanupTTSTempFiles()
+}
+
+// cleanupTTSTempFiles removes any mdviewer_tts_* files left behind in the
+// system temp directory, e.g. by a process that was killed mid-speech before
+// it could remove its own temp file. The different Speaker backends write
+// different extensions (say: .aiff, espeak: .wav, CloudEngine: .mp3), so this
+// matches on the shared prefix rather than a fixed extension.
+func cleanupTTSTempFiles() {
+	entries, err := os.ReadDir(os.TempDir())
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if matched, _ := filepath.Match("mdviewer_tts_*", entry.Name()); matched {
+			os.Remove(filepath.Join(os.TempDir(), entry.Name()))
+		}
+	}
+}
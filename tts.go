@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// TTSEngine synthesizes text into audio bytes. Implementations report the
+// encoding they produce via Format so callers that need MP3 (joinMP3,
+// ID3v2 export) know to transcode first instead of assuming MP3.
+type TTSEngine interface {
+	Synthesize(ctx context.Context, text string, voice string, rate int) ([]byte, error)
+	// Format is the audio encoding Synthesize returns, e.g. "mp3" or "aiff".
+	Format() string
+}
+
+// SayEngine shells out to the macOS `say` command and re-encodes nothing;
+// it is kept as the default engine when no cloud credentials are configured.
+type SayEngine struct{}
+
+// Synthesize renders text via `say` and returns the resulting AIFF bytes.
+func (SayEngine) Synthesize(ctx context.Context, text string, voice string, rate int) ([]byte, error) {
+	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("mdviewer_tts_%d.aiff", time.Now().UnixNano()))
+	defer os.Remove(tmpFile)
+
+	args := []string{"-r", fmt.Sprintf("%d", rate), "-o", tmpFile, "--file-format=AIFF"}
+	if voice != "" && voice != "default" {
+		args = append([]string{"-v", voice}, args...)
+	}
+	args = append(args, text)
+
+	if err := exec.CommandContext(ctx, "say", args...).Run(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(tmpFile)
+}
+
+// Format reports that SayEngine produces AIFF, not MP3.
+func (SayEngine) Format() string { return "aiff" }
+
+const googleTTSEndpoint = "https://texttospeech.googleapis.com/v1/text:synthesize"
+
+// GoogleCloudEngine synthesizes speech via the Google Cloud Text-to-Speech
+// API, submitting SSML so paragraphs keep natural pauses between sentences.
+type GoogleCloudEngine struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewGoogleCloudEngine builds a Cloud TTS engine from an API key. The key is
+// normally sourced from the MDVIEWER_GOOGLE_TTS_API_KEY env var or a settings
+// file; callers are expected to check for an empty key themselves.
+func NewGoogleCloudEngine(apiKey string) *GoogleCloudEngine {
+	return &GoogleCloudEngine{apiKey: apiKey, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Synthesize posts SSML for text to the Cloud TTS API and returns MP3 bytes.
+func (g *GoogleCloudEngine) Synthesize(ctx context.Context, text string, voice string, rate int) ([]byte, error) {
+	if g.apiKey == "" {
+		return nil, fmt.Errorf("google cloud tts: no API key configured")
+	}
+
+	reqBody, err := json.Marshal(map[string]any{
+		"input": map[string]string{"ssml": textToSSML(text)},
+		"voice": map[string]string{"name": voice, "languageCode": voiceLanguageCode(voice)},
+		"audioConfig": map[string]any{
+			"audioEncoding": "MP3",
+			"speakingRate":  rateToSpeakingRate(rate),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := googleTTSEndpoint + "?key=" + g.apiKey
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google cloud tts: unexpected status %s", resp.Status)
+	}
+
+	var out struct {
+		AudioContent string `json:"audioContent"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.AudioContent)
+}
+
+// Format reports that GoogleCloudEngine produces MP3 directly.
+func (g *GoogleCloudEngine) Format() string { return "mp3" }
+
+// textToSSML wraps text in <speak> with a trailing pause, escaping it so the
+// paragraph can't break out of the markup.
+func textToSSML(text string) string {
+	return fmt.Sprintf(`<speak>%s<break time="400ms"/></speak>`, html.EscapeString(text))
+}
+
+// voiceLanguageCode derives a BCP-47 language code from a Cloud TTS voice
+// name such as "en-US-Wavenet-D"; it falls back to US English.
+func voiceLanguageCode(voice string) string {
+	parts := strings.SplitN(voice, "-", 3)
+	if len(parts) >= 2 {
+		return parts[0] + "-" + parts[1]
+	}
+	return "en-US"
+}
+
+// rateToSpeakingRate maps the app's words-per-minute rate knob onto Cloud
+// TTS's speakingRate multiplier (1.0 == normal speed, ~175 wpm).
+func rateToSpeakingRate(rate int) float64 {
+	if rate <= 0 {
+		return 1.0
+	}
+	return float64(rate) / 175.0
+}
+
+// splitParagraphs breaks markdown into blank-line-delimited chunks for
+// per-paragraph synthesis.
+func splitParagraphs(markdown string) []string {
+	raw := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n\n")
+	paragraphs := make([]string, 0, len(raw))
+	for _, p := range raw {
+		if strings.TrimSpace(p) != "" {
+			paragraphs = append(paragraphs, strings.TrimSpace(p))
+		}
+	}
+	return paragraphs
+}
+
+// audioCacheDir returns the temp directory used to cache synthesized
+// paragraph audio, creating it if necessary.
+func audioCacheDir() (string, error) {
+	dir := filepath.Join(os.TempDir(), "mdviewer-tts-cache")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// audioCacheKey derives the cache filename for a given paragraph, voice and
+// rate so edits only invalidate the paragraphs that actually changed.
+func audioCacheKey(voice string, rate int, text string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s", voice, rate, text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// synthesizeCached returns a paragraph's MP3 bytes, synthesizing via the
+// app's TTS engine on a cache miss, transcoding to MP3 if the engine
+// doesn't already produce it, and writing the result back to disk.
+func (a *App) synthesizeCached(ctx context.Context, text string, voice string, rate int) ([]byte, error) {
+	dir, err := audioCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	cachePath := filepath.Join(dir, audioCacheKey(voice, rate, text)+".mp3")
+
+	if cached, err := os.ReadFile(cachePath); err == nil {
+		return cached, nil
+	}
+
+	audio, err := a.ttsEngine.Synthesize(ctx, text, voice, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	audio, err = transcodeToMP3(audio, a.ttsEngine.Format())
+	if err != nil {
+		return nil, err
+	}
+
+	_ = os.WriteFile(cachePath, audio, 0644)
+	return audio, nil
+}
+
+// transcodeToMP3 converts audio from sourceFormat to MP3 via ffmpeg. It is a
+// no-op when sourceFormat is already "mp3", which keeps GoogleCloudEngine's
+// output on the fast path.
+func transcodeToMP3(audio []byte, sourceFormat string) ([]byte, error) {
+	if sourceFormat == "mp3" {
+		return audio, nil
+	}
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return nil, fmt.Errorf("transcoding %s audio to mp3 requires ffmpeg on PATH: %w", sourceFormat, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "mdviewer-transcode")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	inPath := filepath.Join(tmpDir, "in."+sourceFormat)
+	outPath := filepath.Join(tmpDir, "out.mp3")
+	if err := os.WriteFile(inPath, audio, 0644); err != nil {
+		return nil, err
+	}
+	if err := exec.Command("ffmpeg", "-y", "-i", inPath, "-codec:a", "libmp3lame", outPath).Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg transcode failed: %w", err)
+	}
+	return os.ReadFile(outPath)
+}
+
+// joinMP3 concatenates MP3 part streams into a single stream. MP3 frames are
+// self-delimited, so naively appending constant-bitrate streams produced by
+// the same encoder/engine plays back correctly in practice.
+func joinMP3(parts [][]byte) []byte {
+	total := 0
+	for _, p := range parts {
+		total += len(p)
+	}
+	joined := make([]byte, 0, total)
+	for _, p := range parts {
+		joined = append(joined, p...)
+	}
+	return joined
+}
+
+// SynthesizeDocument splits markdown into paragraphs, synthesizes each one
+// concurrently (using the per-paragraph cache), and merges the resulting MP3
+// streams into a single temp file whose path is returned for playback.
+func (a *App) SynthesizeDocument(markdown string, voice string, rate int) (string, error) {
+	paragraphs := splitParagraphs(markdown)
+	if len(paragraphs) == 0 {
+		return "", fmt.Errorf("nothing to synthesize")
+	}
+
+	parts := make([][]byte, len(paragraphs))
+
+	g, ctx := errgroup.WithContext(a.ctx)
+	for i, p := range paragraphs {
+		i, p := i, p
+		g.Go(func() error {
+			audio, err := a.synthesizeCached(ctx, p, voice, rate)
+			if err != nil {
+				return fmt.Errorf("synthesizing paragraph %d: %w", i, err)
+			}
+			parts[i] = audio
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+
+	dir, err := audioCacheDir()
+	if err != nil {
+		return "", err
+	}
+	outPath := filepath.Join(dir, fmt.Sprintf("document_%d.mp3", time.Now().UnixNano()))
+	if err := os.WriteFile(outPath, joinMP3(parts), 0644); err != nil {
+		return "", err
+	}
+	return outPath, nil
+}
@@ -0,0 +1,64 @@
+package tts
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Boundary marks a sentence's position within a part and its estimated
+// offset into playback, so the frontend can karaoke-highlight along as
+// audio plays.
+type Boundary struct {
+	PartIndex int
+	CharStart int
+	CharEnd   int
+	TimeMs    int
+}
+
+var sentenceBoundary = regexp.MustCompile(`[^.!?]+[.!?]*`)
+
+// splitSentences breaks text into sentence spans with their offsets into
+// the original string.
+func splitSentences(text string) []Boundary {
+	var sentences []Boundary
+	for _, loc := range sentenceBoundary.FindAllStringIndex(text, -1) {
+		if strings.TrimSpace(text[loc[0]:loc[1]]) == "" {
+			continue
+		}
+		sentences = append(sentences, Boundary{CharStart: loc[0], CharEnd: loc[1]})
+	}
+	return sentences
+}
+
+// estimateTimings fills in TimeMs for each sentence boundary based on word
+// count at the given words-per-minute rate. This is an approximation used
+// by backends (like `say`) that don't report real synthesis timepoints.
+func estimateTimings(text string, sentences []Boundary, rate int) []Boundary {
+	msPerWord := msPerWord(rate)
+
+	elapsed := 0.0
+	timed := make([]Boundary, len(sentences))
+	for i, s := range sentences {
+		timed[i] = s
+		timed[i].TimeMs = int(elapsed)
+		words := len(strings.Fields(text[s.CharStart:s.CharEnd]))
+		elapsed += float64(words) * msPerWord
+	}
+	return timed
+}
+
+// EstimateDurationMs approximates how long speaking text at the given
+// words-per-minute rate takes. Callers that need chapter/section timing
+// (e.g. ID3v2 chapter markers) without real synthesis timepoints can sum
+// this across the text they've already queued for synthesis.
+func EstimateDurationMs(text string, rate int) int {
+	words := len(strings.Fields(text))
+	return int(float64(words) * msPerWord(rate))
+}
+
+func msPerWord(rate int) float64 {
+	if rate <= 0 {
+		rate = 175
+	}
+	return 60000.0 / float64(rate)
+}
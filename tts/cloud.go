@@ -0,0 +1,329 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const cloudTTSEndpoint = "https://texttospeech.googleapis.com/v1/text:synthesize"
+
+// CloudEngine speaks text via the Google Cloud Text-to-Speech API. Unlike
+// the local engines it returns MP3 bytes, so Speak/SpeakWithBoundaries
+// shell out to whatever local MP3 player is available to actually make
+// sound.
+type CloudEngine struct {
+	apiKey string
+	client *http.Client
+
+	mu      sync.Mutex
+	playCmd *exec.Cmd
+
+	pausedAt    time.Time
+	pausedTotal time.Duration
+}
+
+// NewCloudEngine builds a Speaker backed by the Google Cloud Text-to-Speech
+// API, requiring an API key from MDVIEWER_GOOGLE_TTS_API_KEY or settings.
+func NewCloudEngine(apiKey string) *CloudEngine {
+	return &CloudEngine{apiKey: apiKey, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (e *CloudEngine) Speak(ctx context.Context, text string, voice string, rate int) error {
+	audio, err := e.synthesize(ctx, fmt.Sprintf(`<speak>%s</speak>`, html.EscapeString(text)), voice, rate)
+	if err != nil {
+		return err
+	}
+	return e.play(ctx, audio)
+}
+
+// SpeakWithBoundaries requests SSML with a <mark> before each sentence and
+// asks the API to report timepoints for them, so boundaries reflect the
+// service's own synthesis timing rather than a word-count estimate.
+func (e *CloudEngine) SpeakWithBoundaries(ctx context.Context, text string, voice string, rate int, onBoundary func(Boundary)) error {
+	sentences := splitSentences(text)
+
+	var ssml strings.Builder
+	ssml.WriteString("<speak>")
+	for i, s := range sentences {
+		fmt.Fprintf(&ssml, `<mark name="s%d"/>`, i)
+		ssml.WriteString(html.EscapeString(text[s.CharStart:s.CharEnd]))
+	}
+	ssml.WriteString("</speak>")
+
+	audio, timepoints, err := e.synthesizeWithTimepoints(ctx, ssml.String(), voice, rate)
+	if err != nil {
+		return err
+	}
+
+	for i := range sentences {
+		if ms, ok := timepoints["s"+strconv.Itoa(i)]; ok {
+			sentences[i].TimeMs = ms
+		}
+	}
+
+	emitCtx, cancelEmit := context.WithCancel(ctx)
+	defer cancelEmit()
+
+	playErrCh := make(chan error, 1)
+	go func() {
+		playErrCh <- e.play(ctx, audio)
+		cancelEmit()
+	}()
+
+	start := time.Now()
+	elapsed := func() time.Duration { return e.elapsedPlayback(start) }
+	for _, b := range sentences {
+		waitUntil(emitCtx, elapsed, time.Duration(b.TimeMs)*time.Millisecond)
+		if emitCtx.Err() != nil {
+			break
+		}
+		onBoundary(b)
+	}
+
+	return <-playErrCh
+}
+
+// waitUntil blocks until elapsed() reaches target or ctx is canceled,
+// polling rather than arming a single timer so a pause (which stalls
+// elapsed()) delays the wait correctly.
+func waitUntil(ctx context.Context, elapsed func() time.Duration, target time.Duration) {
+	for target > elapsed() {
+		wait := pollInterval
+		if remaining := target - elapsed(); remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (e *CloudEngine) synthesize(ctx context.Context, ssml string, voice string, rate int) ([]byte, error) {
+	audio, _, err := e.request(ctx, ssml, voice, rate, false)
+	return audio, err
+}
+
+func (e *CloudEngine) synthesizeWithTimepoints(ctx context.Context, ssml string, voice string, rate int) ([]byte, map[string]int, error) {
+	return e.request(ctx, ssml, voice, rate, true)
+}
+
+func (e *CloudEngine) request(ctx context.Context, ssml string, voice string, rate int, withTimepoints bool) ([]byte, map[string]int, error) {
+	if e.apiKey == "" {
+		return nil, nil, fmt.Errorf("google cloud tts: no API key configured")
+	}
+
+	body := map[string]any{
+		"input": map[string]string{"ssml": ssml},
+		"voice": map[string]string{"name": voice, "languageCode": voiceLanguageCode(voice)},
+		"audioConfig": map[string]any{
+			"audioEncoding": "MP3",
+			"speakingRate":  rateToSpeakingRate(rate),
+		},
+	}
+	if withTimepoints {
+		body["enableTimePointing"] = []string{"SSML_MARK"}
+	}
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cloudTTSEndpoint+"?key="+e.apiKey, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("google cloud tts: unexpected status %s", resp.Status)
+	}
+
+	var out struct {
+		AudioContent string `json:"audioContent"`
+		Timepoints   []struct {
+			MarkName    string  `json:"markName"`
+			TimeSeconds float64 `json:"timeSeconds"`
+		} `json:"timepoints"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, nil, err
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(out.AudioContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timepoints := make(map[string]int, len(out.Timepoints))
+	for _, tp := range out.Timepoints {
+		timepoints[tp.MarkName] = int(tp.TimeSeconds * 1000)
+	}
+	return audio, timepoints, nil
+}
+
+// voiceLanguageCode derives a BCP-47 language code from a Cloud TTS voice
+// name such as "en-US-Wavenet-D"; it falls back to US English.
+func voiceLanguageCode(voice string) string {
+	parts := strings.SplitN(voice, "-", 3)
+	if len(parts) >= 2 {
+		return parts[0] + "-" + parts[1]
+	}
+	return "en-US"
+}
+
+// rateToSpeakingRate maps the app's words-per-minute rate knob onto Cloud
+// TTS's speakingRate multiplier (1.0 == normal speed, ~175 wpm).
+func rateToSpeakingRate(rate int) float64 {
+	if rate <= 0 {
+		return 1.0
+	}
+	return float64(rate) / 175.0
+}
+
+// play writes MP3 bytes to a temp file and plays it with a local player,
+// tracking the process so Pause/Resume/Stop can control it.
+func (e *CloudEngine) play(ctx context.Context, audio []byte) error {
+	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("mdviewer_tts_%d.mp3", time.Now().UnixNano()))
+	if err := os.WriteFile(tmpFile, audio, 0644); err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	player, args := mp3PlayerCommand(tmpFile)
+	playCmd := exec.CommandContext(ctx, player, args...)
+
+	e.mu.Lock()
+	e.playCmd = playCmd
+	e.pausedAt = time.Time{}
+	e.pausedTotal = 0
+	e.mu.Unlock()
+
+	err := playCmd.Run()
+
+	e.mu.Lock()
+	e.playCmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+// mp3PlayerCommand picks a local player for an MP3 file by platform,
+// falling back to tools more likely to be preinstalled on Linux.
+func mp3PlayerCommand(path string) (string, []string) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "afplay", []string{path}
+	default:
+		if _, err := exec.LookPath("mpg123"); err == nil {
+			return "mpg123", []string{"-q", path}
+		}
+		return "ffplay", []string{"-nodisp", "-autoexit", "-loglevel", "quiet", path}
+	}
+}
+
+func (e *CloudEngine) Voices() ([]Voice, error) {
+	if e.apiKey == "" {
+		return nil, fmt.Errorf("google cloud tts: no API key configured")
+	}
+
+	resp, err := e.client.Get("https://texttospeech.googleapis.com/v1/voices?key=" + e.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google cloud tts: unexpected status %s", resp.Status)
+	}
+
+	var out struct {
+		Voices []struct {
+			Name          string   `json:"name"`
+			LanguageCodes []string `json:"languageCodes"`
+			SsmlGender    string   `json:"ssmlGender"`
+		} `json:"voices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	voices := make([]Voice, 0, len(out.Voices))
+	for _, v := range out.Voices {
+		lang := ""
+		if len(v.LanguageCodes) > 0 {
+			lang = v.LanguageCodes[0]
+		}
+		voices = append(voices, Voice{ID: v.Name, Name: v.Name, Language: lang, Gender: v.SsmlGender})
+	}
+	return voices, nil
+}
+
+func (e *CloudEngine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd != nil && e.playCmd.Process != nil {
+		return e.playCmd.Process.Kill()
+	}
+	return nil
+}
+
+func (e *CloudEngine) Pause() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	if err := pauseProcess(e.playCmd.Process); err != nil {
+		return err
+	}
+	e.pausedAt = time.Now()
+	return nil
+}
+
+func (e *CloudEngine) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	if err := resumeProcess(e.playCmd.Process); err != nil {
+		return err
+	}
+	if !e.pausedAt.IsZero() {
+		e.pausedTotal += time.Since(e.pausedAt)
+		e.pausedAt = time.Time{}
+	}
+	return nil
+}
+
+func (e *CloudEngine) elapsedPlayback(start time.Time) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	paused := e.pausedTotal
+	if !e.pausedAt.IsZero() {
+		paused += time.Since(e.pausedAt)
+	}
+	return time.Since(start) - paused
+}
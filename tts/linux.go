@@ -0,0 +1,160 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LinuxEngine speaks text on Linux via espeak-ng (falling back to spd-say),
+// rendering to a WAV file and playing it with whichever of aplay/paplay is
+// on PATH.
+type LinuxEngine struct {
+	mu      sync.Mutex
+	playCmd *exec.Cmd
+}
+
+// NewLinuxEngine returns a Speaker backed by espeak-ng/spd-say and
+// aplay/paplay.
+func NewLinuxEngine() *LinuxEngine {
+	return &LinuxEngine{}
+}
+
+func (e *LinuxEngine) Speak(ctx context.Context, text string, voice string, rate int) error {
+	if _, err := exec.LookPath("espeak-ng"); err == nil {
+		return e.speakEspeak(ctx, text, voice, rate)
+	}
+	if _, err := exec.LookPath("spd-say"); err == nil {
+		return e.speakSpdSay(ctx, text, voice, rate)
+	}
+	return fmt.Errorf("tts: no Linux speech engine found (tried espeak-ng, spd-say)")
+}
+
+// speakEspeak renders text to a WAV file via espeak-ng and plays it with
+// whichever of aplay/paplay is on PATH; unlike speakSpdSay it needs a
+// separate player, so the player check lives here rather than in Speak.
+func (e *LinuxEngine) speakEspeak(ctx context.Context, text string, voice string, rate int) error {
+	player := findPlayer()
+	if player == "" {
+		return fmt.Errorf("tts: no audio player found (tried aplay, paplay)")
+	}
+
+	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("mdviewer_tts_%d.wav", time.Now().UnixNano()))
+	defer os.Remove(tmpFile)
+
+	args := []string{"-s", fmt.Sprintf("%d", rate), "-w", tmpFile}
+	if voice != "" && voice != "default" {
+		args = append(args, "-v", voice)
+	}
+	args = append(args, text)
+
+	if err := exec.CommandContext(ctx, "espeak-ng", args...).Run(); err != nil {
+		return err
+	}
+
+	playCmd := exec.CommandContext(ctx, player, tmpFile)
+	e.mu.Lock()
+	e.playCmd = playCmd
+	e.mu.Unlock()
+
+	err := playCmd.Run()
+
+	e.mu.Lock()
+	e.playCmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+func (e *LinuxEngine) speakSpdSay(ctx context.Context, text string, voice string, rate int) error {
+	args := []string{"-r", fmt.Sprintf("%d", normalizeSpdRate(rate)), "-w"}
+	if voice != "" && voice != "default" {
+		args = append(args, "-y", voice)
+	}
+	args = append(args, text)
+
+	cmd := exec.CommandContext(ctx, "spd-say", args...)
+	e.mu.Lock()
+	e.playCmd = cmd
+	e.mu.Unlock()
+
+	err := cmd.Run()
+
+	e.mu.Lock()
+	e.playCmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+// normalizeSpdRate clamps a words-per-minute rate into spd-say's -100..100
+// relative speed range.
+func normalizeSpdRate(rate int) int {
+	relative := (rate - 175) / 2
+	if relative < -100 {
+		return -100
+	}
+	if relative > 100 {
+		return 100
+	}
+	return relative
+}
+
+func findPlayer() string {
+	for _, candidate := range []string{"paplay", "aplay"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (e *LinuxEngine) Voices() ([]Voice, error) {
+	output, err := exec.Command("espeak-ng", "--voices").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var voices []Voice
+	lines := strings.Split(string(output), "\n")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		voices = append(voices, Voice{ID: fields[3], Name: fields[3], Language: fields[1]})
+	}
+	return voices, nil
+}
+
+func (e *LinuxEngine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd != nil && e.playCmd.Process != nil {
+		return e.playCmd.Process.Kill()
+	}
+	return nil
+}
+
+func (e *LinuxEngine) Pause() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	return pauseProcess(e.playCmd.Process)
+}
+
+func (e *LinuxEngine) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	return resumeProcess(e.playCmd.Process)
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package tts
+
+import (
+	"os"
+	"syscall"
+)
+
+// pauseProcess suspends a running process via SIGSTOP.
+func pauseProcess(p *os.Process) error {
+	return p.Signal(syscall.SIGSTOP)
+}
+
+// resumeProcess resumes a process previously suspended with pauseProcess.
+func resumeProcess(p *os.Process) error {
+	return p.Signal(syscall.SIGCONT)
+}
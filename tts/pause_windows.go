@@ -0,0 +1,18 @@
+//go:build windows
+
+package tts
+
+import (
+	"fmt"
+	"os"
+)
+
+// pauseProcess has no SIGSTOP equivalent on Windows without suspending
+// individual threads via the Win32 API, which is out of scope here.
+func pauseProcess(p *os.Process) error {
+	return fmt.Errorf("tts: pause is not supported on Windows")
+}
+
+func resumeProcess(p *os.Process) error {
+	return fmt.Errorf("tts: resume is not supported on Windows")
+}
@@ -0,0 +1,112 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// PiperEngine speaks text with a local Piper neural-TTS binary, piping text
+// in on stdin and streaming the resulting raw 16-bit PCM (piper's
+// --output-raw mode, not a WAV stream) from stdout straight into a player
+// rather than writing an intermediate file.
+type PiperEngine struct {
+	binPath string
+	model   string
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+}
+
+// NewPiperEngine returns a Speaker backed by a local `piper` binary. model
+// is the path to a Piper voice model (.onnx); the binary is resolved as
+// "piper" from PATH.
+func NewPiperEngine(model string) *PiperEngine {
+	return &PiperEngine{binPath: "piper", model: model}
+}
+
+func (e *PiperEngine) Speak(ctx context.Context, text string, voice string, rate int) error {
+	if e.model == "" && voice == "" {
+		return fmt.Errorf("tts: piper engine requires a voice model path")
+	}
+	model := e.model
+	if voice != "" {
+		model = voice
+	}
+
+	player := findPlayer()
+	if player == "" {
+		player = "aplay"
+	}
+
+	piperCmd := exec.CommandContext(ctx, e.binPath, "--model", model, "--output-raw", "--length-scale", lengthScale(rate))
+	piperCmd.Stdin = strings.NewReader(text)
+
+	piperOut, err := piperCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	playCmd := exec.CommandContext(ctx, player, "-r", "22050", "-f", "S16_LE", "-t", "raw", "-")
+	playCmd.Stdin = piperOut
+
+	e.mu.Lock()
+	e.cmd = piperCmd
+	e.mu.Unlock()
+
+	if err := playCmd.Start(); err != nil {
+		return err
+	}
+	if err := piperCmd.Run(); err != nil {
+		return err
+	}
+	err = playCmd.Wait()
+
+	e.mu.Lock()
+	e.cmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+// lengthScale converts words-per-minute into Piper's inverse length-scale
+// knob (lower is faster).
+func lengthScale(rate int) string {
+	if rate <= 0 {
+		rate = 175
+	}
+	return fmt.Sprintf("%.2f", 175.0/float64(rate))
+}
+
+func (e *PiperEngine) Voices() ([]Voice, error) {
+	return nil, fmt.Errorf("tts: piper voices are selected by model file path, not a voice list")
+}
+
+func (e *PiperEngine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd != nil && e.cmd.Process != nil {
+		return e.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (e *PiperEngine) Pause() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd == nil || e.cmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	return pauseProcess(e.cmd.Process)
+}
+
+func (e *PiperEngine) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd == nil || e.cmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	return resumeProcess(e.cmd.Process)
+}
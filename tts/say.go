@@ -0,0 +1,203 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SayEngine speaks text on macOS via `say`, writing an intermediate AIFF
+// file and playing it with `afplay` so Speak only returns once playback has
+// actually finished.
+type SayEngine struct {
+	mu      sync.Mutex
+	playCmd *exec.Cmd
+
+	// pausedAt/pausedTotal track time spent paused during the current
+	// SpeakWithBoundaries call so its boundary clock can discount it.
+	pausedAt    time.Time
+	pausedTotal time.Duration
+}
+
+// NewSayEngine returns a Speaker backed by the macOS `say`/`afplay` tools.
+func NewSayEngine() *SayEngine {
+	return &SayEngine{}
+}
+
+func (e *SayEngine) Speak(ctx context.Context, text string, voice string, rate int) error {
+	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("mdviewer_tts_%d.aiff", time.Now().UnixNano()))
+	defer os.Remove(tmpFile)
+
+	args := []string{"-r", fmt.Sprintf("%d", rate), "-o", tmpFile, "--file-format=AIFF"}
+	if voice != "" && voice != "default" {
+		args = append([]string{"-v", voice}, args...)
+	}
+	args = append(args, text)
+
+	if err := exec.CommandContext(ctx, "say", args...).Run(); err != nil {
+		return err
+	}
+
+	playCmd := exec.CommandContext(ctx, "afplay", tmpFile)
+	e.mu.Lock()
+	e.playCmd = playCmd
+	e.mu.Unlock()
+
+	err := playCmd.Run()
+
+	e.mu.Lock()
+	e.playCmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+func (e *SayEngine) Voices() ([]Voice, error) {
+	output, err := exec.Command("say", "-v", "?").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var voices []Voice
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		voices = append(voices, Voice{ID: fields[0], Name: fields[0], Language: fields[1]})
+	}
+	return voices, nil
+}
+
+func (e *SayEngine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd != nil && e.playCmd.Process != nil {
+		return e.playCmd.Process.Kill()
+	}
+	return nil
+}
+
+func (e *SayEngine) Pause() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	if err := pauseProcess(e.playCmd.Process); err != nil {
+		return err
+	}
+	e.pausedAt = time.Now()
+	return nil
+}
+
+func (e *SayEngine) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.playCmd == nil || e.playCmd.Process == nil {
+		return fmt.Errorf("tts: nothing playing")
+	}
+	if err := resumeProcess(e.playCmd.Process); err != nil {
+		return err
+	}
+	if !e.pausedAt.IsZero() {
+		e.pausedTotal += time.Since(e.pausedAt)
+		e.pausedAt = time.Time{}
+	}
+	return nil
+}
+
+// elapsedPlayback returns how long playback has actually been running
+// since start, excluding any time currently or previously spent paused, so
+// SpeakWithBoundaries' events stay in sync across Pause/Resume.
+func (e *SayEngine) elapsedPlayback(start time.Time) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	paused := e.pausedTotal
+	if !e.pausedAt.IsZero() {
+		paused += time.Since(e.pausedAt)
+	}
+	return time.Since(start) - paused
+}
+
+// SpeakWithBoundaries synthesizes and plays text like Speak, additionally
+// invoking onBoundary once per sentence at its estimated offset. `say` has
+// no public API for real synthesis timepoints, so offsets are approximated
+// from word count at the requested rate.
+func (e *SayEngine) SpeakWithBoundaries(ctx context.Context, text string, voice string, rate int, onBoundary func(Boundary)) error {
+	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("mdviewer_tts_%d.aiff", time.Now().UnixNano()))
+	defer os.Remove(tmpFile)
+
+	args := []string{"-r", fmt.Sprintf("%d", rate), "-o", tmpFile, "--file-format=AIFF"}
+	if voice != "" && voice != "default" {
+		args = append([]string{"-v", voice}, args...)
+	}
+	args = append(args, text)
+
+	if err := exec.CommandContext(ctx, "say", args...).Run(); err != nil {
+		return err
+	}
+
+	playCmd := exec.CommandContext(ctx, "afplay", tmpFile)
+	e.mu.Lock()
+	e.playCmd = playCmd
+	e.pausedAt = time.Time{}
+	e.pausedTotal = 0
+	e.mu.Unlock()
+
+	if err := playCmd.Start(); err != nil {
+		e.mu.Lock()
+		e.playCmd = nil
+		e.mu.Unlock()
+		return err
+	}
+
+	start := time.Now()
+	emitCtx, cancelEmit := context.WithCancel(ctx)
+	go emitBoundaries(emitCtx, text, rate, func() time.Duration { return e.elapsedPlayback(start) }, onBoundary)
+
+	err := playCmd.Wait()
+	cancelEmit()
+
+	e.mu.Lock()
+	e.playCmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+// pollInterval bounds how long emitBoundaries can oversleep a boundary
+// while playback is paused: it re-checks elapsed() this often instead of
+// arming a single timer that would ignore pauses.
+const pollInterval = 50 * time.Millisecond
+
+// emitBoundaries waits for each sentence's estimated offset, as reported by
+// elapsed (which stalls while playback is paused), and calls onBoundary;
+// it stops early if ctx is canceled.
+func emitBoundaries(ctx context.Context, text string, rate int, elapsed func() time.Duration, onBoundary func(Boundary)) {
+	timed := estimateTimings(text, splitSentences(text), rate)
+
+	for _, b := range timed {
+		target := time.Duration(b.TimeMs) * time.Millisecond
+		for target > elapsed() {
+			wait := pollInterval
+			if remaining := target - elapsed(); remaining < wait {
+				wait = remaining
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+		onBoundary(b)
+	}
+}
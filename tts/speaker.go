@@ -0,0 +1,79 @@
+// Package tts provides cross-platform text-to-speech playback for live
+// line-by-line reading. It is distinct from the document-export synthesis in
+// the main package: Speaker implementations play audio immediately and can
+// be stopped mid-utterance, rather than returning encoded bytes.
+package tts
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// Voice describes a TTS voice available on the current backend.
+type Voice struct {
+	ID       string
+	Name     string
+	Language string
+	Gender   string
+}
+
+// Speaker speaks text aloud through whatever backend is available on the
+// host platform.
+type Speaker interface {
+	// Speak synthesizes and plays text, blocking until playback finishes or
+	// ctx is canceled.
+	Speak(ctx context.Context, text string, voice string, rate int) error
+	// Voices lists the voices this backend can speak with.
+	Voices() ([]Voice, error)
+	// Stop kills the backend's running playback process, if any. A Speak
+	// call that is still synthesizing, before playback has started, is not
+	// interrupted.
+	Stop() error
+	// Pause suspends in-progress playback; Resume continues it. Backends
+	// with no suspend primitive (Windows) return an error.
+	Pause() error
+	Resume() error
+}
+
+// BoundarySpeaker is implemented by backends that can report sentence
+// boundaries as playback progresses, for karaoke-style highlighting.
+// onBoundary may be called from a background goroutine while
+// Speak-equivalent playback is in progress.
+type BoundarySpeaker interface {
+	SpeakWithBoundaries(ctx context.Context, text string, voice string, rate int, onBoundary func(Boundary)) error
+}
+
+// New constructs the Speaker registered under name. An empty name selects
+// the platform default for runtime.GOOS.
+func New(name string) (Speaker, error) {
+	if name == "" {
+		name = DefaultEngineName()
+	}
+
+	switch name {
+	case "say":
+		return NewSayEngine(), nil
+	case "linux":
+		return NewLinuxEngine(), nil
+	case "windows":
+		return NewWindowsEngine(), nil
+	case "piper":
+		return NewPiperEngine(""), nil
+	default:
+		return nil, fmt.Errorf("tts: unknown engine %q", name)
+	}
+}
+
+// DefaultEngineName returns the Speaker name appropriate for the current
+// GOOS, used to pick a default when the user hasn't overridden it.
+func DefaultEngineName() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "say"
+	case "windows":
+		return "windows"
+	default:
+		return "linux"
+	}
+}
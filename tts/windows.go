@@ -0,0 +1,112 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WindowsEngine speaks text via PowerShell's
+// System.Speech.Synthesis.SpeechSynthesizer, so it needs no extra runtime
+// beyond what ships with Windows.
+type WindowsEngine struct {
+	mu  sync.Mutex
+	cmd *exec.Cmd
+}
+
+// NewWindowsEngine returns a Speaker backed by PowerShell's SpeechSynthesizer.
+func NewWindowsEngine() *WindowsEngine {
+	return &WindowsEngine{}
+}
+
+// speakScript reads the text and voice to speak from environment variables
+// rather than interpolating them into the script source: text comes from
+// an untrusted markdown document, and a value substituted into a
+// double-quoted PowerShell string would have `$(...)` subexpressions
+// inside it expanded and executed.
+const speakScript = `
+Add-Type -AssemblyName System.Speech
+$synth = New-Object System.Speech.Synthesis.SpeechSynthesizer
+$voice = $env:MDVIEWER_TTS_VOICE
+if ($voice) { $synth.SelectVoice($voice) }
+$synth.Rate = [int]$env:MDVIEWER_TTS_RATE
+$synth.Speak($env:MDVIEWER_TTS_TEXT)
+`
+
+func (e *WindowsEngine) Speak(ctx context.Context, text string, voice string, rate int) error {
+	cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", speakScript)
+	cmd.Env = append(cmd.Environ(),
+		"MDVIEWER_TTS_TEXT="+text,
+		"MDVIEWER_TTS_VOICE="+voice,
+		"MDVIEWER_TTS_RATE="+strconv.Itoa(rateToSAPIRate(rate)),
+	)
+
+	e.mu.Lock()
+	e.cmd = cmd
+	e.mu.Unlock()
+
+	err := cmd.Run()
+
+	e.mu.Lock()
+	e.cmd = nil
+	e.mu.Unlock()
+
+	return err
+}
+
+// rateToSAPIRate maps words-per-minute onto SAPI's -10..10 relative rate.
+func rateToSAPIRate(rate int) int {
+	relative := (rate - 175) / 10
+	if relative < -10 {
+		return -10
+	}
+	if relative > 10 {
+		return 10
+	}
+	return relative
+}
+
+func (e *WindowsEngine) Voices() ([]Voice, error) {
+	const script = `
+Add-Type -AssemblyName System.Speech
+$synth = New-Object System.Speech.Synthesis.SpeechSynthesizer
+$synth.GetInstalledVoices() | ForEach-Object { $_.VoiceInfo.Name + "|" + $_.VoiceInfo.Culture + "|" + $_.VoiceInfo.Gender }
+`
+	output, err := exec.Command("powershell", "-NoProfile", "-Command", script).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var voices []Voice
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "|")
+		if len(fields) != 3 {
+			continue
+		}
+		voices = append(voices, Voice{ID: fields[0], Name: fields[0], Language: fields[1], Gender: fields[2]})
+	}
+	return voices, nil
+}
+
+func (e *WindowsEngine) Stop() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd != nil && e.cmd.Process != nil {
+		return e.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// Pause/Resume have no SAPI equivalent wired up here; SpeechSynthesizer
+// exposes Pause()/Resume() in-process, but we only talk to it via a
+// one-shot PowerShell script rather than a long-lived session.
+func (e *WindowsEngine) Pause() error {
+	return fmt.Errorf("tts: pause is not supported by the Windows engine")
+}
+
+func (e *WindowsEngine) Resume() error {
+	return fmt.Errorf("tts: resume is not supported by the Windows engine")
+}
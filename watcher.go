@@ -0,0 +1,220 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const defaultWatchDebounce = 150 * time.Millisecond
+
+var imageRefPattern = regexp.MustCompile(`!\[[^\]]*\]\(([^)\s]+)\)`)
+
+// fileWatcher tails a single markdown file (plus any images it references)
+// and debounces disk change notifications into a single re-read.
+type fileWatcher struct {
+	mu       sync.Mutex
+	watcher  *fsnotify.Watcher
+	timer    *time.Timer
+	stopped  bool
+	onChange func()
+}
+
+// newFileWatcher watches path and every sibling image it references via
+// markdown image syntax, calling onChange (debounced by debounceMs) after
+// any of them is written.
+func newFileWatcher(path string, debounceMs int, onChange func()) (*fileWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Watch the containing directory rather than the file itself: editors
+	// commonly save by renaming a temp file over the original, which would
+	// otherwise silently stop a direct file watch.
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	// Image directories are best-effort: a draft referencing an image that
+	// doesn't exist yet (or whose directory is missing) shouldn't take down
+	// watching of the document itself.
+	imgDirs := map[string]bool{}
+	for _, img := range referencedImages(path) {
+		imgDirs[filepath.Dir(img)] = true
+	}
+	for dir := range imgDirs {
+		if dir == filepath.Dir(path) {
+			continue
+		}
+		if err := w.Add(dir); err != nil {
+			println("watcher: not watching image directory", dir+":", err.Error())
+			continue
+		}
+	}
+
+	fw := &fileWatcher{watcher: w}
+	debounce := time.Duration(debounceMs) * time.Millisecond
+	if debounce <= 0 {
+		debounce = defaultWatchDebounce
+	}
+
+	// watcher.Events reports filepath.Clean'd paths, so the watched set must
+	// be keyed on cleaned paths too or a non-canonical a.currentFile (e.g.
+	// containing "./") would never match and file:changed would silently
+	// never fire.
+	watched := watchedSet(filepath.Clean(path))
+	for _, img := range referencedImages(path) {
+		watched[filepath.Clean(img)] = true
+	}
+
+	go fw.run(watched, debounce, onChange)
+
+	return fw, nil
+}
+
+func watchedSet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// referencedImages extracts local image paths referenced by markdown
+// image syntax in the file at path, resolved relative to its directory.
+func referencedImages(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	var images []string
+	for _, match := range imageRefPattern.FindAllStringSubmatch(string(content), -1) {
+		ref := match[1]
+		if strings.Contains(ref, "://") {
+			continue // skip remote images
+		}
+		images = append(images, filepath.Join(dir, ref))
+	}
+	return images
+}
+
+func (fw *fileWatcher) run(watched map[string]bool, debounce time.Duration, onChange func()) {
+	for {
+		select {
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			if !watched[filepath.Clean(event.Name)] {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			fw.debounced(debounce, onChange)
+		case _, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (fw *fileWatcher) debounced(d time.Duration, onChange func()) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.stopped {
+		return
+	}
+	if fw.timer != nil {
+		fw.timer.Stop()
+	}
+	fw.timer = time.AfterFunc(d, onChange)
+}
+
+func (fw *fileWatcher) Close() error {
+	fw.mu.Lock()
+	fw.stopped = true
+	if fw.timer != nil {
+		fw.timer.Stop()
+	}
+	fw.mu.Unlock()
+	return fw.watcher.Close()
+}
+
+// WatchCurrentFile starts or stops watching a.currentFile for external
+// changes. Enabling replaces any existing watcher; disabling tears it down.
+func (a *App) WatchCurrentFile(enable bool) error {
+	a.watchMu.Lock()
+	defer a.watchMu.Unlock()
+
+	a.watchEnabled = enable
+	return a.restartWatchLocked()
+}
+
+// SetWatchDebounceMs changes how long the watcher waits after the last
+// detected change before re-reading the file, and restarts any active watch
+// so the new value takes effect immediately.
+func (a *App) SetWatchDebounceMs(ms int) {
+	a.watchMu.Lock()
+	defer a.watchMu.Unlock()
+
+	a.watchDebounceMs = ms
+	_ = a.restartWatchLocked()
+}
+
+// restartWatchLocked tears down any existing watcher and, if watching is
+// enabled and a file is open, starts a new one for a.currentFile. Callers
+// must hold a.watchMu.
+func (a *App) restartWatchLocked() error {
+	if a.watcher != nil {
+		a.watcher.Close()
+		a.watcher = nil
+	}
+
+	if !a.watchEnabled || a.currentFile == "" {
+		return nil
+	}
+
+	path := a.currentFile
+	fw, err := newFileWatcher(path, a.watchDebounceMs, func() {
+		a.handleFileChanged(path)
+	})
+	if err != nil {
+		return err
+	}
+	a.watcher = fw
+	return nil
+}
+
+// handleFileChanged re-reads path (if it's still the current file) and
+// notifies the frontend so the preview can refresh.
+func (a *App) handleFileChanged(path string) {
+	a.watchMu.Lock()
+	isCurrent := path == a.currentFile
+	a.watchMu.Unlock()
+	if !isCurrent {
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	a.content = string(content)
+
+	runtime.EventsEmit(a.ctx, "file:changed", map[string]string{
+		"path":    path,
+		"content": a.content,
+	})
+}